@@ -0,0 +1,69 @@
+package mapbox
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// APIError carries the diagnostics Mapbox returns in an error response
+// body, alongside the HTTP status code and request ID, so that callers can
+// inspect them instead of parsing an error string.
+//
+// APIError always wraps one of the sentinel errors in this package (e.g.
+// ErrNotFound, ErrAlreadyExists), so callers can branch with errors.Is.
+type APIError struct {
+	StatusCode int
+	Message    string
+	Errors     []string
+	RequestID  string
+
+	sentinel error
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "mapbox: %v (status %d)", e.Message, e.StatusCode)
+	if len(e.Errors) > 0 {
+		fmt.Fprintf(&b, ", errors: %v", strings.Join(e.Errors, ","))
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, ", request id: %v", e.RequestID)
+	}
+	return b.String()
+}
+
+// Unwrap allows errors.Is(err, ErrNotFound) and similar checks against the
+// sentinel this APIError was classified as.
+func (e *APIError) Unwrap() error {
+	return e.sentinel
+}
+
+// newAPIError builds an APIError from a Mapbox error response body,
+// classifying it against the package's sentinel errors based on the HTTP
+// status code and, where the status code alone is ambiguous, the message
+// body.
+func newAPIError(resp *http.Response, body UpdateTilesetErrResponse) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    body.Message,
+		Errors:     body.Errors,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+		sentinel:   ErrOperation,
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		e.sentinel = ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests:
+		e.sentinel = ErrRateLimited
+	case strings.Contains(body.Message, "already exists"):
+		e.sentinel = ErrAlreadyExists
+	case resp.StatusCode == http.StatusBadRequest && strings.Contains(body.Message, "recipe"):
+		e.sentinel = ErrRecipeInvalid
+	case resp.StatusCode == http.StatusBadRequest:
+		e.sentinel = ErrValidation
+	}
+
+	return e
+}
@@ -5,11 +5,40 @@ import (
 	"io"
 	"mime/multipart"
 	"net/http"
+	"strings"
 	"sync"
 
 	"golang.org/x/net/context/ctxhttp"
 )
 
+// parseNextLink extracts the URL of the rel="next" entry from a Link
+// response header, as used by Mapbox's cursor-based list pagination. It
+// returns an empty string if there is no next page.
+func parseNextLink(header http.Header) string {
+	for _, link := range strings.Split(header.Get("Link"), ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+
+		url := strings.TrimSpace(parts[0])
+		url = strings.TrimPrefix(url, "<")
+		url = strings.TrimSuffix(url, ">")
+
+		isNext := false
+		for _, param := range parts[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				isNext = true
+				break
+			}
+		}
+		if isNext {
+			return url
+		}
+	}
+	return ""
+}
+
 // putMultiPart uploads the file provided by path to a URL using PUT.
 func putMultipart(ctx context.Context, client *http.Client, url string, filename string, r io.Reader) (*http.Response, error) {
 	return doMultipart(ctx, client, http.MethodPut, url, filename, r)
@@ -60,6 +89,9 @@ func doMultipart(ctx context.Context, client *http.Client, method string, url st
 	if writeErr != nil {
 		return nil, writeErr
 	}
+	if err != nil {
+		return nil, err
+	}
 
 	return resp, nil
 }
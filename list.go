@@ -0,0 +1,301 @@
+package mapbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"golang.org/x/net/context/ctxhttp"
+)
+
+// Tileset describes a tileset as returned by ListTilesets and GetTileset.
+type Tileset struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Center      []float64 `json:"center"`
+	Bounds      []float64 `json:"bounds"`
+	Created     string    `json:"created"`
+	Modified    string    `json:"modified"`
+	Type        string    `json:"type"`
+	Visibility  string    `json:"visibility"`
+	Status      string    `json:"status"`
+}
+
+// ListTilesetsOptions narrows the result set returned by ListTilesets.
+type ListTilesetsOptions struct {
+	// Limit caps the number of tilesets returned per page. Mapbox defaults
+	// to 100 when zero.
+	Limit int
+
+	// SortBy orders results, e.g. "created" or "modified".
+	SortBy string
+
+	// Type filters to "raster" or "vector" tilesets. Empty returns both.
+	Type string
+}
+
+// ListTilesets returns an iterator over the tilesets owned by the client's
+// username, lazily fetching subsequent pages as the iterator is advanced.
+func (c *Client) ListTilesets(ctx context.Context, opts ListTilesetsOptions) (*TilesetIter, error) {
+	url := baseURL + "/tilesets/v1/" + c.username + "?access_token=" + c.accessToken
+	if opts.Limit > 0 {
+		url += fmt.Sprintf("&limit=%d", opts.Limit)
+	}
+	if opts.SortBy != "" {
+		url += "&sortby=" + opts.SortBy
+	}
+	if opts.Type != "" {
+		url += "&type=" + opts.Type
+	}
+
+	it := &TilesetIter{client: c, nextURL: url}
+	return it, nil
+}
+
+// TilesetIter iterates over a paginated list of tilesets. Call Next to
+// advance, Value to read the current tileset, and Err after Next returns
+// false to check for a fetch error.
+type TilesetIter struct {
+	client  *Client
+	items   []Tileset
+	idx     int
+	nextURL string
+	err     error
+}
+
+// Next fetches the next page of tilesets if needed and advances the
+// iterator. It returns false once there are no more tilesets or an error
+// occurs.
+func (it *TilesetIter) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.idx++
+	if it.idx < len(it.items) {
+		return true
+	}
+
+	if it.nextURL == "" {
+		return false
+	}
+
+	req, err := http.NewRequest(http.MethodGet, it.nextURL, nil)
+	if err != nil {
+		it.err = fmt.Errorf("%w error, failed to create http request: %v", ErrUnexpected, err)
+		return false
+	}
+
+	resp, err := ctxhttp.Do(ctx, it.client.httpClient, req)
+	if err != nil {
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			it.err = rlErr
+			return false
+		}
+		it.err = fmt.Errorf("list tilesets %w failed, err: %v", ErrOperation, err)
+		return false
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var jsonResp UpdateTilesetErrResponse
+		if err := json.NewDecoder(resp.Body).Decode(&jsonResp); err != nil {
+			it.err = fmt.Errorf("%w of list tilesets response failed, err: %v", ErrParse, err)
+			return false
+		}
+		it.err = newAPIError(resp, jsonResp)
+		return false
+	}
+
+	var items []Tileset
+	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+		it.err = fmt.Errorf("%w of list tilesets response failed, err: %v", ErrParse, err)
+		return false
+	}
+
+	it.items = items
+	it.idx = 0
+	it.nextURL = parseNextLink(resp.Header)
+
+	return len(it.items) > 0
+}
+
+// Value returns the tileset at the iterator's current position. It is only
+// valid after a call to Next that returned true.
+func (it *TilesetIter) Value() Tileset {
+	return it.items[it.idx]
+}
+
+// Err returns the first error encountered while fetching pages, if any.
+func (it *TilesetIter) Err() error {
+	return it.err
+}
+
+// GetTileset fetches a single tileset's metadata.
+func (c *Client) GetTileset(ctx context.Context, tileset string) (Tileset, error) {
+	var t Tileset
+	if !strings.HasPrefix(tileset, c.username) {
+		tileset = c.username + "." + tileset
+	}
+
+	url := baseURL + "/tilesets/v1/" + tileset + "?access_token=" + c.accessToken
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return t, fmt.Errorf("%w error, failed to create http request: %v", ErrUnexpected, err)
+	}
+
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			return t, rlErr
+		}
+		return t, fmt.Errorf("get tileset %w failed, err: %v", ErrOperation, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return t, fmt.Errorf("tileset %v %w", tileset, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var jsonResp UpdateTilesetErrResponse
+		if err := json.NewDecoder(resp.Body).Decode(&jsonResp); err != nil {
+			return t, fmt.Errorf("%w of get tileset response failed, err: %v", ErrParse, err)
+		}
+		return t, newAPIError(resp, jsonResp)
+	}
+
+	var tilesets []Tileset
+	if err := json.NewDecoder(resp.Body).Decode(&tilesets); err != nil {
+		return t, fmt.Errorf("%w of get tileset response failed, err: %v", ErrParse, err)
+	}
+	if len(tilesets) == 0 {
+		return t, fmt.Errorf("tileset %v %w", tileset, ErrNotFound)
+	}
+
+	return tilesets[0], nil
+}
+
+// DeleteTileset deletes a tileset and all of its publish jobs. It does not
+// delete the tileset's sources.
+func (c *Client) DeleteTileset(ctx context.Context, tileset string) error {
+	if !strings.HasPrefix(tileset, c.username) {
+		tileset = c.username + "." + tileset
+	}
+
+	url := baseURL + "/tilesets/v1/" + tileset + "?access_token=" + c.accessToken
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return fmt.Errorf("%w error, failed to create http request: %v", ErrUnexpected, err)
+	}
+
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			return rlErr
+		}
+		return fmt.Errorf("delete tileset %w failed, err: %v", ErrOperation, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("tileset %v %w", tileset, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		var jsonResp UpdateTilesetErrResponse
+		if err := json.NewDecoder(resp.Body).Decode(&jsonResp); err != nil {
+			return fmt.Errorf("%w of delete tileset response failed, err: %v", ErrParse, err)
+		}
+		return newAPIError(resp, jsonResp)
+	}
+
+	return nil
+}
+
+// GetTilesetJobs returns an iterator over the publish jobs for tileset,
+// optionally filtered to a single stage. An empty stage returns jobs in
+// every stage.
+func (c *Client) GetTilesetJobs(ctx context.Context, tileset string, stage PublishJobStage) (*PublishJobIter, error) {
+	if !strings.HasPrefix(tileset, c.username) {
+		tileset = c.username + "." + tileset
+	}
+
+	url := baseURL + "/tilesets/v1/" + tileset + "/jobs?access_token=" + c.accessToken
+	if stage != "" {
+		url += "&stage=" + string(stage)
+	}
+
+	return &PublishJobIter{client: c, nextURL: url}, nil
+}
+
+// PublishJobIter iterates over a paginated list of publish jobs.
+type PublishJobIter struct {
+	client  *Client
+	items   []PollPublishJobResponse
+	idx     int
+	nextURL string
+	err     error
+}
+
+// Next fetches the next page of jobs if needed and advances the iterator.
+func (it *PublishJobIter) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.idx++
+	if it.idx < len(it.items) {
+		return true
+	}
+
+	if it.nextURL == "" {
+		return false
+	}
+
+	req, err := http.NewRequest(http.MethodGet, it.nextURL, nil)
+	if err != nil {
+		it.err = fmt.Errorf("%w error, failed to create http request: %v", ErrUnexpected, err)
+		return false
+	}
+
+	resp, err := ctxhttp.Do(ctx, it.client.httpClient, req)
+	if err != nil {
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			it.err = rlErr
+			return false
+		}
+		it.err = fmt.Errorf("list tileset jobs %w failed, err: %v", ErrOperation, err)
+		return false
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var jsonResp UpdateTilesetErrResponse
+		if err := json.NewDecoder(resp.Body).Decode(&jsonResp); err != nil {
+			it.err = fmt.Errorf("%w of list tileset jobs response failed, err: %v", ErrParse, err)
+			return false
+		}
+		it.err = newAPIError(resp, jsonResp)
+		return false
+	}
+
+	var items []PollPublishJobResponse
+	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+		it.err = fmt.Errorf("%w of list tileset jobs response failed, err: %v", ErrParse, err)
+		return false
+	}
+
+	it.items = items
+	it.idx = 0
+	it.nextURL = parseNextLink(resp.Header)
+
+	return len(it.items) > 0
+}
+
+// Value returns the job at the iterator's current position.
+func (it *PublishJobIter) Value() PollPublishJobResponse {
+	return it.items[it.idx]
+}
+
+// Err returns the first error encountered while fetching pages, if any.
+func (it *PublishJobIter) Err() error {
+	return it.err
+}
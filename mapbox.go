@@ -12,6 +12,8 @@ type Client struct {
 	username    string
 	accessToken string
 	httpClient  *http.Client
+	retryPolicy RetryPolicy
+	rateLimit   int
 }
 
 var (
@@ -19,10 +21,49 @@ var (
 	ErrOperation  = errors.New("upload")
 	ErrParse      = errors.New("parse")
 	ErrUnexpected = errors.New("unexpected")
+
+	// ErrAlreadyExists is returned when a create operation targets a
+	// resource that already exists.
+	ErrAlreadyExists = errors.New("already exists")
+
+	// ErrNotFound is returned when an operation targets a resource that
+	// does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrRateLimited is returned when Mapbox rejects a request with
+	// HTTP 429, after any configured retries have been exhausted.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrRecipeInvalid is returned when Mapbox rejects a tileset recipe
+	// as invalid.
+	ErrRecipeInvalid = errors.New("recipe invalid")
 )
 
+// ClientOption configures optional behaviour on a Client created via
+// NewClient.
+type ClientOption func(*Client)
+
+// WithHTTPClient overrides the *http.Client used to make requests. Its
+// Transport, if set, is wrapped to apply the configured retry policy and
+// rate limit.
+func WithHTTPClient(hc *http.Client) ClientOption {
+	return func(c *Client) { c.httpClient = hc }
+}
+
+// WithRetryPolicy configures retry behaviour for idempotent requests
+// (GET/PUT/PATCH) that fail with a transient 5xx or are rate limited.
+func WithRetryPolicy(p RetryPolicy) ClientOption {
+	return func(c *Client) { c.retryPolicy = p }
+}
+
+// WithRateLimit caps outgoing requests to rps requests per second. A
+// non-positive rps disables rate limiting, which is the default.
+func WithRateLimit(rps int) ClientOption {
+	return func(c *Client) { c.rateLimit = rps }
+}
+
 // NewClient returns a new Mapbox client which interacts with the Mapbox API.
-func NewClient(accessToken string, username string) (Client, error) {
+func NewClient(accessToken string, username string, opts ...ClientOption) (Client, error) {
 	var c Client
 	if len(username) == 0 {
 		return c, fmt.Errorf("%w: username is required", ErrValidation)
@@ -33,5 +74,19 @@ func NewClient(accessToken string, username string) (Client, error) {
 	c.username = username
 	c.accessToken = accessToken
 	c.httpClient = http.DefaultClient
+	c.retryPolicy = DefaultRetryPolicy
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	transport := c.httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	wrapped := *c.httpClient
+	wrapped.Transport = newRetryTransport(transport, c.retryPolicy, c.rateLimit)
+	c.httpClient = &wrapped
+
 	return c, nil
 }
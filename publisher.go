@@ -0,0 +1,145 @@
+package mapbox
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/cenkalti/backoff/v4"
+)
+
+// TilesetSpec describes a tileset to be created, or updated, and published
+// in a single operation.
+type TilesetSpec struct {
+	// ID is the tileset ID, with or without the username prefix.
+	ID string
+
+	// Recipe is the recipe to upsert before publishing.
+	Recipe TilesetRecipe
+
+	// GeoJSON is read to populate the tileset source. One GeoJSON feature
+	// per line is expected, matching the format required by
+	// Client.CreateTilesetSource.
+	GeoJSON io.Reader
+}
+
+// Observer receives progress events while a TilesetPublisher runs. The
+// package provides no default implementation: a non-nil Observer must
+// implement all three methods.
+type Observer interface {
+	// OnStageChange is called whenever the publish job transitions to a
+	// new PublishJobStage.
+	OnStageChange(stage PublishJobStage)
+
+	// OnLayerStats is called once layer statistics become available on a
+	// poll response.
+	OnLayerStats(layerStats map[string]interface{})
+
+	// OnWarnings is called whenever a poll response carries one or more
+	// warnings, even if the job later succeeds.
+	OnWarnings(warnings []PublishJobMessage)
+}
+
+// PublishJobError wraps the errors reported by a failed publish job.
+type PublishJobError struct {
+	Tileset string
+	JobID   string
+	Details []PublishJobMessage
+}
+
+func (e *PublishJobError) Error() string {
+	return fmt.Sprintf("tileset %v job %v %v: %v", e.Tileset, e.JobID, ErrOperation, e.Details)
+}
+
+func (e *PublishJobError) Unwrap() error {
+	return ErrOperation
+}
+
+// TilesetPublisher orchestrates the source upload, recipe upsert, publish,
+// and job polling steps required to publish a tileset, so that callers do
+// not have to sequence and poll these calls themselves.
+type TilesetPublisher struct {
+	client   *Client
+	observer Observer
+}
+
+// NewTilesetPublisher returns a TilesetPublisher bound to client. The
+// observer may be nil, in which case progress events are discarded.
+func NewTilesetPublisher(client *Client, observer Observer) *TilesetPublisher {
+	return &TilesetPublisher{client: client, observer: observer}
+}
+
+// Run drives spec through source upload, recipe upsert, publish, and
+// polling, honouring ctx cancellation throughout. It returns once the
+// publish job reaches a terminal stage (success or failed), or once ctx is
+// cancelled or a step fails outright.
+func (p *TilesetPublisher) Run(ctx context.Context, spec TilesetSpec) (*PollPublishJobResponse, error) {
+	if _, err := p.client.CreateTilesetSource(ctx, spec.ID, spec.GeoJSON); err != nil {
+		return nil, err
+	}
+
+	if err := p.client.UpsertTileset(ctx, spec.ID, spec.Recipe); err != nil {
+		return nil, err
+	}
+
+	job, err := p.client.PublishTileset(ctx, spec.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastStage PublishJobStage
+	var resp *PollPublishJobResponse
+
+	poll := func() error {
+		resp, err = job.Poll(ctx)
+		if err != nil {
+			return err
+		}
+
+		if resp.Stage != lastStage {
+			lastStage = resp.Stage
+			if p.observer != nil {
+				p.observer.OnStageChange(resp.Stage)
+			}
+		}
+
+		if len(resp.LayerStats) > 0 && p.observer != nil {
+			p.observer.OnLayerStats(resp.LayerStats)
+		}
+
+		if len(resp.Warnings) > 0 && p.observer != nil {
+			p.observer.OnWarnings(resp.Warnings)
+		}
+
+		switch resp.Stage {
+		case PublishJobStageSuccess:
+			return nil
+		case PublishJobStageFailed:
+			return backoff.Permanent(&PublishJobError{
+				Tileset: job.Tileset,
+				JobID:   job.JobID,
+				Details: resp.Errors,
+			})
+		default:
+			return fmt.Errorf("job %v still %v", job.JobID, resp.Stage)
+		}
+	}
+
+	// MaxElapsedTime defaults to 15 minutes in cenkalti/backoff, which would
+	// give up on perfectly healthy, still-running publish jobs. ctx is the
+	// only deadline Run should honour.
+	exp := backoff.NewExponentialBackOff()
+	exp.MaxElapsedTime = 0
+	bo := backoff.WithContext(exp, ctx)
+	if err := backoff.Retry(poll, bo); err != nil {
+		if pErr, ok := err.(*PublishJobError); ok {
+			return resp, pErr
+		}
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			return resp, rlErr
+		}
+		return resp, fmt.Errorf("poll job %w failed, err: %v", ErrOperation, err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,212 @@
+package mapbox
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	recipeMinZoom = 0
+	recipeMaxZoom = 16
+)
+
+var (
+	layerNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9_]{1,256}$`)
+	sourceURIRegexp = regexp.MustCompile(`^mapbox://tileset-source/[^/]+/[^/]+$`)
+)
+
+// RecipeBuilder builds a TilesetRecipe one layer at a time, validating each
+// layer against MTS's recipe constraints locally before Build returns it.
+// This avoids the round trip of submitting an invalid recipe and parsing
+// Mapbox's rejection.
+type RecipeBuilder struct {
+	recipe TilesetRecipe
+	order  []string
+	probs  []string
+}
+
+// NewRecipeBuilder returns a RecipeBuilder for a recipe using MTS recipe
+// format version 1.
+func NewRecipeBuilder() *RecipeBuilder {
+	return &RecipeBuilder{
+		recipe: TilesetRecipe{
+			Version: 1,
+			Layers:  map[string]TilesetRecipeLayer{},
+		},
+	}
+}
+
+// Layer adds a layer to the recipe, sourced from a single tileset source
+// URI, visible between minZoom and maxZoom inclusive.
+func (b *RecipeBuilder) Layer(name string, source string, minZoom int, maxZoom int) *RecipeBuilder {
+	return b.addLayer(name, TilesetRecipeLayer{
+		Source:  source,
+		MinZoom: minZoom,
+		MaxZoom: maxZoom,
+	})
+}
+
+// UnionLayer adds a layer built by combining features from source and
+// unionSource, rather than a single source. It is mutually exclusive with
+// Layer for the same layer name.
+func (b *RecipeBuilder) UnionLayer(name string, source string, unionSource string, minZoom int, maxZoom int) *RecipeBuilder {
+	return b.addLayer(name, TilesetRecipeLayer{
+		MinZoom: minZoom,
+		MaxZoom: maxZoom,
+		Tiles: &TilesetRecipeTiles{
+			Union: &TilesetRecipeUnion{Sources: []string{source, unionSource}},
+		},
+	})
+}
+
+func (b *RecipeBuilder) addLayer(name string, layer TilesetRecipeLayer) *RecipeBuilder {
+	if _, ok := b.recipe.Layers[name]; !ok {
+		b.order = append(b.order, name)
+	}
+	b.recipe.Layers[name] = layer
+	return b
+}
+
+// WithSource sets, or overrides, the source URI for an existing layer.
+func (b *RecipeBuilder) WithSource(name string, source string) *RecipeBuilder {
+	layer := b.recipe.Layers[name]
+	layer.Source = source
+	return b.addLayer(name, layer)
+}
+
+// WithFilter sets a Mapbox GL-style feature filter expression on name.
+func (b *RecipeBuilder) WithFilter(name string, filter []interface{}) *RecipeBuilder {
+	layer := b.recipe.Layers[name]
+	layer.Features = ensureFeatures(layer.Features)
+	layer.Features.Filter = filter
+	return b.addLayer(name, layer)
+}
+
+// WithAttributeAllowlist restricts which feature properties are kept on
+// name's output features.
+func (b *RecipeBuilder) WithAttributeAllowlist(name string, allow []string) *RecipeBuilder {
+	layer := b.recipe.Layers[name]
+	layer.Features = ensureFeatures(layer.Features)
+	if layer.Features.Attributes == nil {
+		layer.Features.Attributes = &TilesetRecipeAttributes{}
+	}
+	layer.Features.Attributes.Allow = allow
+	return b.addLayer(name, layer)
+}
+
+// WithBufferSize sets the tile buffer size, in pixels, for name.
+func (b *RecipeBuilder) WithBufferSize(name string, pixels int) *RecipeBuilder {
+	layer := b.recipe.Layers[name]
+	layer.Tiles = ensureTiles(layer.Tiles)
+	layer.Tiles.BufferSize = pixels
+	return b.addLayer(name, layer)
+}
+
+// WithLayerSize sets the target vector tile layer size, in kilobytes, for
+// name.
+func (b *RecipeBuilder) WithLayerSize(name string, kilobytes int) *RecipeBuilder {
+	layer := b.recipe.Layers[name]
+	layer.Tiles = ensureTiles(layer.Tiles)
+	layer.Tiles.LayerSize = kilobytes
+	return b.addLayer(name, layer)
+}
+
+func ensureFeatures(f *TilesetRecipeFeatures) *TilesetRecipeFeatures {
+	if f == nil {
+		return &TilesetRecipeFeatures{}
+	}
+	return f
+}
+
+func ensureTiles(t *TilesetRecipeTiles) *TilesetRecipeTiles {
+	if t == nil {
+		return &TilesetRecipeTiles{}
+	}
+	return t
+}
+
+// Build validates every layer added so far and returns the assembled
+// recipe. If any layer violates an MTS recipe constraint, Build returns an
+// ErrValidation-wrapped error listing every problem found, across all
+// layers, in one pass.
+func (b *RecipeBuilder) Build() (TilesetRecipe, error) {
+	var probs []string
+
+	if len(b.recipe.Layers) == 0 {
+		probs = append(probs, "recipe must have at least one layer")
+	}
+
+	for _, name := range b.order {
+		layer := b.recipe.Layers[name]
+		probs = append(probs, validateLayer(name, layer)...)
+	}
+
+	if len(probs) > 0 {
+		return TilesetRecipe{}, fmt.Errorf("%w: %v", ErrValidation, strings.Join(probs, "; "))
+	}
+
+	return b.recipe, nil
+}
+
+func validateLayer(name string, layer TilesetRecipeLayer) []string {
+	var probs []string
+
+	if !layerNameRegexp.MatchString(name) {
+		probs = append(probs, fmt.Sprintf("layer %q: name must match %v", name, layerNameRegexp))
+	}
+
+	if layer.MinZoom < recipeMinZoom || layer.MinZoom > recipeMaxZoom {
+		probs = append(probs, fmt.Sprintf("layer %q: minzoom %d out of range [%d, %d]", name, layer.MinZoom, recipeMinZoom, recipeMaxZoom))
+	}
+	if layer.MaxZoom < recipeMinZoom || layer.MaxZoom > recipeMaxZoom {
+		probs = append(probs, fmt.Sprintf("layer %q: maxzoom %d out of range [%d, %d]", name, layer.MaxZoom, recipeMinZoom, recipeMaxZoom))
+	}
+	if layer.MinZoom > layer.MaxZoom {
+		probs = append(probs, fmt.Sprintf("layer %q: minzoom %d is greater than maxzoom %d", name, layer.MinZoom, layer.MaxZoom))
+	}
+
+	hasUnion := layer.Tiles != nil && layer.Tiles.Union != nil
+	if hasUnion && layer.Source != "" {
+		probs = append(probs, fmt.Sprintf("layer %q: source and tiles.union are mutually exclusive", name))
+	}
+	if !hasUnion && layer.Source == "" {
+		probs = append(probs, fmt.Sprintf("layer %q: source is required unless tiles.union is set", name))
+	}
+
+	if layer.Source != "" && !sourceURIRegexp.MatchString(layer.Source) {
+		probs = append(probs, fmt.Sprintf("layer %q: source %q must match mapbox://tileset-source/{username}/{name}", name, layer.Source))
+	}
+	if hasUnion {
+		if len(layer.Tiles.Union.Sources) < 2 {
+			probs = append(probs, fmt.Sprintf("layer %q: tiles.union must combine at least two sources", name))
+		}
+		for _, src := range layer.Tiles.Union.Sources {
+			if !sourceURIRegexp.MatchString(src) {
+				probs = append(probs, fmt.Sprintf("layer %q: tiles.union.source %q must match mapbox://tileset-source/{username}/{name}", name, src))
+			}
+		}
+	}
+
+	if layer.Features != nil && layer.Features.Attributes != nil {
+		attrs := layer.Features.Attributes
+		if len(attrs.Allow) > 0 && len(attrs.Set) > 0 {
+			for setName := range attrs.Set {
+				if !containsString(attrs.Allow, setName) {
+					probs = append(probs, fmt.Sprintf("layer %q: attributes.set %q is not present in attributes.allow", name, setName))
+				}
+			}
+		}
+	}
+
+	return probs
+}
+
+func containsString(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,159 @@
+package mapbox
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRecipeBuilder_Valid(t *testing.T) {
+	recipe, err := NewRecipeBuilder().
+		Layer("roads", "mapbox://tileset-source/user/roads", 0, 10).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if recipe.Version != 1 {
+		t.Errorf("Version = %v, want 1", recipe.Version)
+	}
+	if len(recipe.Layers) != 1 {
+		t.Errorf("len(Layers) = %v, want 1", len(recipe.Layers))
+	}
+}
+
+func TestRecipeBuilder_EmptyRecipe(t *testing.T) {
+	_, err := NewRecipeBuilder().Build()
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("err = %v, want ErrValidation", err)
+	}
+}
+
+func TestRecipeBuilder_SourceAndUnionMutuallyExclusive(t *testing.T) {
+	_, err := NewRecipeBuilder().
+		UnionLayer("roads", "mapbox://tileset-source/user/a", "mapbox://tileset-source/user/b", 0, 10).
+		WithSource("roads", "mapbox://tileset-source/user/c").
+		Build()
+
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("err = %v, want ErrValidation", err)
+	}
+	if !contains(err.Error(), "mutually exclusive") {
+		t.Errorf("err = %v, want mention of mutual exclusion", err)
+	}
+}
+
+func TestRecipeBuilder_MissingSource(t *testing.T) {
+	_, err := NewRecipeBuilder().
+		Layer("roads", "", 0, 10).
+		Build()
+
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("err = %v, want ErrValidation", err)
+	}
+	if !contains(err.Error(), "source is required") {
+		t.Errorf("err = %v, want mention of missing source", err)
+	}
+}
+
+func TestRecipeBuilder_UnionRequiresAtLeastTwoSources(t *testing.T) {
+	recipe, err := NewRecipeBuilder().
+		Layer("roads", "mapbox://tileset-source/user/roads", 0, 10).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	layer := recipe.Layers["roads"]
+	layer.Source = ""
+	layer.Tiles = &TilesetRecipeTiles{Union: &TilesetRecipeUnion{Sources: []string{"mapbox://tileset-source/user/roads"}}}
+
+	probs := validateLayer("roads", layer)
+	if !containsAny(probs, "at least two sources") {
+		t.Errorf("probs = %v, want a problem about needing at least two sources", probs)
+	}
+}
+
+func TestRecipeBuilder_InvalidSourceURI(t *testing.T) {
+	_, err := NewRecipeBuilder().
+		Layer("roads", "not-a-valid-uri", 0, 10).
+		Build()
+
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("err = %v, want ErrValidation", err)
+	}
+	if !contains(err.Error(), "must match mapbox://tileset-source") {
+		t.Errorf("err = %v, want mention of the source URI format", err)
+	}
+}
+
+func TestRecipeBuilder_ZoomRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		min     int
+		max     int
+		wantErr bool
+	}{
+		{"valid range", 0, 16, false},
+		{"min below floor", -1, 10, true},
+		{"max above ceiling", 0, 17, true},
+		{"min greater than max", 10, 5, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewRecipeBuilder().
+				Layer("roads", "mapbox://tileset-source/user/roads", tt.min, tt.max).
+				Build()
+
+			got := errors.Is(err, ErrValidation)
+			if got != tt.wantErr {
+				t.Errorf("errors.Is(err, ErrValidation) = %v, want %v (err: %v)", got, tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRecipeBuilder_InvalidLayerName(t *testing.T) {
+	_, err := NewRecipeBuilder().
+		Layer("bad name!", "mapbox://tileset-source/user/roads", 0, 10).
+		Build()
+
+	if !errors.Is(err, ErrValidation) {
+		t.Fatalf("err = %v, want ErrValidation", err)
+	}
+}
+
+func TestRecipeBuilder_AttributesSetMustBeInAllowlist(t *testing.T) {
+	_, err := NewRecipeBuilder().
+		Layer("roads", "mapbox://tileset-source/user/roads", 0, 10).
+		WithAttributeAllowlist("roads", []string{"name"}).
+		Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	recipe, _ := NewRecipeBuilder().
+		Layer("roads", "mapbox://tileset-source/user/roads", 0, 10).
+		WithAttributeAllowlist("roads", []string{"name"}).
+		Build()
+	layer := recipe.Layers["roads"]
+	layer.Features.Attributes.Set = map[string]interface{}{"other": 1}
+
+	probs := validateLayer("roads", layer)
+	if !containsAny(probs, "is not present in attributes.allow") {
+		t.Errorf("probs = %v, want a problem about attributes.set not being allowed", probs)
+	}
+}
+
+func contains(s, substr string) bool {
+	return strings.Contains(s, substr)
+}
+
+func containsAny(probs []string, substr string) bool {
+	for _, p := range probs {
+		if strings.Contains(p, substr) {
+			return true
+		}
+	}
+	return false
+}
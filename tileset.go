@@ -27,11 +27,83 @@ type TilesetRecipe struct {
 type TilesetRecipeLayer struct {
 	// Source is the URI to a tileset source, on the format:
 	// mapbox://tileset-source/{username}/{tilesetName}
-	Source string `json:"source"`
+	//
+	// Source is mutually exclusive with Tiles.Union, which builds a layer
+	// out of more than one source instead.
+	Source string `json:"source,omitempty"`
 
 	// Min and MaxZoom sets the interval for which the layer is visible.
 	MinZoom int `json:"minzoom"`
 	MaxZoom int `json:"maxzoom"`
+
+	// Features configures feature-level processing: filtering,
+	// simplification, and attribute allowlisting.
+	Features *TilesetRecipeFeatures `json:"features,omitempty"`
+
+	// Tiles configures tile-level processing: buffer and layer size, and
+	// combining multiple sources into one layer.
+	Tiles *TilesetRecipeTiles `json:"tiles,omitempty"`
+}
+
+// TilesetRecipeFeatures configures how individual features are processed
+// before tiling.
+type TilesetRecipeFeatures struct {
+	// Filter is a Mapbox GL-style filter expression, e.g.
+	// []interface{}{"==", []interface{}{"get", "class"}, "park"}.
+	Filter []interface{} `json:"filter,omitempty"`
+
+	// BBox restricts features to a [west, south, east, north] bounding box.
+	BBox []float64 `json:"bbox,omitempty"`
+
+	// Simplification is the simplification factor applied to feature
+	// geometries. Higher values simplify more aggressively.
+	Simplification float64 `json:"simplification,omitempty"`
+
+	// Limit caps the number of features per tile, keeping the
+	// highest-density ones first.
+	Limit int `json:"limit,omitempty"`
+
+	// Attributes controls which feature properties are kept or computed.
+	Attributes *TilesetRecipeAttributes `json:"attributes,omitempty"`
+}
+
+// TilesetRecipeAttributes controls which feature properties end up in the
+// tiled output.
+type TilesetRecipeAttributes struct {
+	// Allow is an allowlist of property names to retain. All other
+	// properties are dropped.
+	Allow []string `json:"allow,omitempty"`
+
+	// Set computes additional properties from expressions, keyed by the
+	// property name to set.
+	Set map[string]interface{} `json:"set,omitempty"`
+}
+
+// TilesetRecipeTiles configures tile-level generation settings.
+type TilesetRecipeTiles struct {
+	// LayerSize is the target vector tile layer size in kilobytes.
+	LayerSize int `json:"layer_size,omitempty"`
+
+	// BufferSize is the tile buffer size in pixels, used to avoid clipping
+	// artifacts at tile edges.
+	BufferSize int `json:"buffer_size,omitempty"`
+
+	// Union combines features from multiple tileset sources into this
+	// layer. Mutually exclusive with TilesetRecipeLayer.Source.
+	Union *TilesetRecipeUnion `json:"union,omitempty"`
+}
+
+// TilesetRecipeUnion combines features from more than one tileset source
+// into a single layer. It is mutually exclusive with
+// TilesetRecipeLayer.Source, which sources a layer from a single tileset
+// source instead.
+type TilesetRecipeUnion struct {
+	// Sources lists the tileset sources to combine, each a URI on the
+	// format: mapbox://tileset-source/{username}/{tilesetName}
+	Sources []string `json:"source"`
+
+	// SourceLayer optionally selects a single layer out of Sources.
+	SourceLayer string `json:"source_layer,omitempty"`
 }
 
 type UpdateTilesetErrResponse struct {
@@ -64,6 +136,9 @@ func (c *Client) UpsertTileset(ctx context.Context, tileset string, recipe Tiles
 
 	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
 	if err != nil {
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			return rlErr
+		}
 		return fmt.Errorf("tileset upload %w failed, err: %v", ErrOperation, err)
 	}
 
@@ -77,13 +152,14 @@ func (c *Client) UpsertTileset(ctx context.Context, tileset string, recipe Tiles
 		return fmt.Errorf("%w of tileset update response failed, err: %v", ErrParse, err)
 	}
 
-	// BadRequest is returned when there is a resource conflict, in which case
-	// the message contains the string "already exists".
-	if strings.Contains(jsonResp.Message, "already exists") {
+	apiErr := newAPIError(resp, jsonResp)
+
+	// BadRequest is returned when there is a resource conflict.
+	if errors.Is(apiErr, ErrAlreadyExists) {
 		return c.UpdateTilesetRecipe(ctx, tileset, recipe)
 	}
 
-	return errors.New(jsonResp.Message + ", errors: " + strings.Join(jsonResp.Errors, ","))
+	return apiErr
 }
 
 // UpdateTilesetRecipe replaces an existing recipe for the provided tileset.
@@ -109,6 +185,9 @@ func (c *Client) UpdateTilesetRecipe(ctx context.Context, tileset string, recipe
 
 	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
 	if err != nil {
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			return rlErr
+		}
 		return fmt.Errorf("upload recipe %w failed, err: %v", ErrOperation, err)
 	}
 
@@ -121,7 +200,7 @@ func (c *Client) UpdateTilesetRecipe(ctx context.Context, tileset string, recipe
 		return fmt.Errorf("%w of tileset update response failed, err: %v", ErrParse, err)
 	}
 
-	return errors.New(jsonResp.Message + ", errors: " + strings.Join(jsonResp.Errors, ","))
+	return newAPIError(resp, jsonResp)
 }
 
 // PublishTilesetJob is a pollable resource that returns the status of a publish job.
@@ -162,6 +241,9 @@ func (c *Client) PublishTileset(ctx context.Context, tileset string) (PublishTil
 
 	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
 	if err != nil {
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			return job, rlErr
+		}
 		return job, fmt.Errorf("publish tileset %w failed, err: %v", ErrOperation, err)
 	}
 
@@ -206,11 +288,42 @@ type PollPublishJobResponse struct {
 	CreatedNice string                 `json:"created_nice"`
 	Published   int                    `json:"published"`
 	TilesetID   string                 `json:"tileset_id"`
-	Errors      []interface{}          `json:"errors"`
-	Warnings    []interface{}          `json:"warnings"`
+	Errors      []PublishJobMessage    `json:"errors"`
+	Warnings    []PublishJobMessage    `json:"warnings"`
 	LayerStats  map[string]interface{} `json:"layer_stats"`
 }
 
+// PublishJobMessage is a single entry from a publish job's "errors" or
+// "warnings" array. Mapbox reports these as either a bare string or an
+// object carrying at least a "message" field, so PublishJobMessage accepts
+// both and normalizes to Message, keeping any other object fields in Raw.
+type PublishJobMessage struct {
+	Message string
+	Raw     map[string]interface{}
+}
+
+func (m PublishJobMessage) String() string {
+	return m.Message
+}
+
+func (m *PublishJobMessage) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		m.Message = s
+		return nil
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("%w failed to decode publish job message: %v", ErrParse, err)
+	}
+	m.Raw = raw
+	if msg, ok := raw["message"].(string); ok {
+		m.Message = msg
+	}
+	return nil
+}
+
 // Poll returns the the status for a publish job.
 func (j *PublishTilesetJob) Poll(ctx context.Context) (*PollPublishJobResponse, error) {
 	url := baseURL + "/tilesets/v1/" + j.Tileset + "/jobs/" + j.JobID +
@@ -223,6 +336,9 @@ func (j *PublishTilesetJob) Poll(ctx context.Context) (*PollPublishJobResponse,
 
 	resp, err := ctxhttp.Do(ctx, j.client.httpClient, req)
 	if err != nil {
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			return nil, rlErr
+		}
 		return nil, fmt.Errorf("poll job %w failed, err: %v", ErrOperation, err)
 	}
 
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+
+	"golang.org/x/net/context/ctxhttp"
 )
 
 // CreateTilesetSource creates a new tileset source.
@@ -44,6 +46,9 @@ func (c *Client) PutTilesetSource(ctx context.Context, tilesetID string, jsonRea
 	var jsonResp NewTilesetSourceResponse
 	resp, err := putMultipart(ctx, c.httpClient, url, "filenamedoesntmatter", jsonReader)
 	if err != nil {
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			return jsonResp, rlErr
+		}
 		return jsonResp, fmt.Errorf("upload %w failed, %v", ErrOperation, err)
 	}
 
@@ -57,3 +62,129 @@ func (c *Client) PutTilesetSource(ctx context.Context, tilesetID string, jsonRea
 
 	return jsonResp, nil
 }
+
+// TilesetSourceInfo describes a tileset source as returned by
+// ListTilesetSources.
+type TilesetSourceInfo struct {
+	ID       string `json:"id"`
+	Files    int    `json:"files"`
+	Size     int    `json:"size"`
+	Created  string `json:"created"`
+	Modified string `json:"modified"`
+}
+
+// ListTilesetSources returns an iterator over the tileset sources owned by
+// the client's username, lazily fetching subsequent pages as the iterator
+// is advanced.
+func (c *Client) ListTilesetSources(ctx context.Context) (*TilesetSourceIter, error) {
+	url := baseURL + "/tilesets/v1/sources/" + c.username + "?access_token=" + c.accessToken
+	return &TilesetSourceIter{client: c, nextURL: url}, nil
+}
+
+// TilesetSourceIter iterates over a paginated list of tileset sources.
+type TilesetSourceIter struct {
+	client  *Client
+	items   []TilesetSourceInfo
+	idx     int
+	nextURL string
+	err     error
+}
+
+// Next fetches the next page of tileset sources if needed and advances the
+// iterator.
+func (it *TilesetSourceIter) Next(ctx context.Context) bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.idx++
+	if it.idx < len(it.items) {
+		return true
+	}
+
+	if it.nextURL == "" {
+		return false
+	}
+
+	req, err := http.NewRequest(http.MethodGet, it.nextURL, nil)
+	if err != nil {
+		it.err = fmt.Errorf("%w error, failed to create http request: %v", ErrUnexpected, err)
+		return false
+	}
+
+	resp, err := ctxhttp.Do(ctx, it.client.httpClient, req)
+	if err != nil {
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			it.err = rlErr
+			return false
+		}
+		it.err = fmt.Errorf("list tileset sources %w failed, err: %v", ErrOperation, err)
+		return false
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var jsonResp UpdateTilesetErrResponse
+		if err := json.NewDecoder(resp.Body).Decode(&jsonResp); err != nil {
+			it.err = fmt.Errorf("%w of list tileset sources response failed, err: %v", ErrParse, err)
+			return false
+		}
+		it.err = newAPIError(resp, jsonResp)
+		return false
+	}
+
+	var items []TilesetSourceInfo
+	if err := json.NewDecoder(resp.Body).Decode(&items); err != nil {
+		it.err = fmt.Errorf("%w of list tileset sources response failed, err: %v", ErrParse, err)
+		return false
+	}
+
+	it.items = items
+	it.idx = 0
+	it.nextURL = parseNextLink(resp.Header)
+
+	return len(it.items) > 0
+}
+
+// Value returns the tileset source at the iterator's current position.
+func (it *TilesetSourceIter) Value() TilesetSourceInfo {
+	return it.items[it.idx]
+}
+
+// Err returns the first error encountered while fetching pages, if any.
+func (it *TilesetSourceIter) Err() error {
+	return it.err
+}
+
+// DeleteTilesetSource deletes a tileset source. Tilesets published from it
+// are not affected.
+func (c *Client) DeleteTilesetSource(ctx context.Context, tilesetSourceID string) error {
+	url := baseURL +
+		"/tilesets/v1/sources/" + c.username + "/" + tilesetSourceID +
+		"?access_token=" + c.accessToken
+
+	req, err := http.NewRequest(http.MethodDelete, url, nil)
+	if err != nil {
+		return fmt.Errorf("%w error, failed to create http request: %v", ErrUnexpected, err)
+	}
+
+	resp, err := ctxhttp.Do(ctx, c.httpClient, req)
+	if err != nil {
+		if rlErr := asRateLimitError(err); rlErr != nil {
+			return rlErr
+		}
+		return fmt.Errorf("delete tileset source %w failed, err: %v", ErrOperation, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("tileset source %v %w", tilesetSourceID, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		var jsonResp UpdateTilesetErrResponse
+		if err := json.NewDecoder(resp.Body).Decode(&jsonResp); err != nil {
+			return fmt.Errorf("%w of delete tileset source response failed, err: %v", ErrParse, err)
+		}
+		return newAPIError(resp, jsonResp)
+	}
+
+	return nil
+}
@@ -0,0 +1,304 @@
+package mapbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/paulmach/orb/geojson"
+)
+
+// FeatureIterator yields GeoJSON features one at a time, so that a GeoJSON
+// feature collection never has to be fully materialized in memory before
+// being uploaded to a tileset source.
+type FeatureIterator interface {
+	// Next advances the iterator and reports whether a feature is
+	// available. It returns false once the iterator is exhausted or an
+	// error occurs, at which point Err should be checked.
+	Next() bool
+
+	// Feature returns the feature produced by the most recent call to
+	// Next.
+	Feature() *geojson.Feature
+
+	// Err returns the first error encountered while iterating, if any.
+	Err() error
+}
+
+// NewFeatureSliceIterator returns a FeatureIterator over an in-memory slice
+// of features.
+func NewFeatureSliceIterator(features []*geojson.Feature) FeatureIterator {
+	return &sliceFeatureIterator{features: features, idx: -1}
+}
+
+type sliceFeatureIterator struct {
+	features []*geojson.Feature
+	idx      int
+}
+
+func (it *sliceFeatureIterator) Next() bool {
+	it.idx++
+	return it.idx < len(it.features)
+}
+
+func (it *sliceFeatureIterator) Feature() *geojson.Feature {
+	return it.features[it.idx]
+}
+
+func (it *sliceFeatureIterator) Err() error { return nil }
+
+// NewFeatureChanIterator returns a FeatureIterator that reads features from
+// ch until it is closed.
+func NewFeatureChanIterator(ch <-chan *geojson.Feature) FeatureIterator {
+	return &chanFeatureIterator{ch: ch}
+}
+
+type chanFeatureIterator struct {
+	ch  <-chan *geojson.Feature
+	cur *geojson.Feature
+}
+
+func (it *chanFeatureIterator) Next() bool {
+	f, ok := <-it.ch
+	it.cur = f
+	return ok
+}
+
+func (it *chanFeatureIterator) Feature() *geojson.Feature { return it.cur }
+
+func (it *chanFeatureIterator) Err() error { return nil }
+
+// NewFeatureCollectionIterator returns a FeatureIterator that streams the
+// "features" array out of a GeoJSON FeatureCollection document read from r,
+// decoding one feature at a time rather than loading the whole collection
+// into memory.
+func NewFeatureCollectionIterator(r io.Reader) (FeatureIterator, error) {
+	dec := json.NewDecoder(r)
+
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("%w failed to find features array: %v", ErrParse, err)
+		}
+		if key, ok := tok.(string); ok && key == "features" {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("%w failed to read features array: %v", ErrParse, err)
+			}
+			if d, ok := tok.(json.Delim); !ok || d != '[' {
+				return nil, fmt.Errorf("%w expected features to be an array", ErrParse)
+			}
+			return &decoderFeatureIterator{dec: dec}, nil
+		}
+	}
+}
+
+type decoderFeatureIterator struct {
+	dec *json.Decoder
+	cur *geojson.Feature
+	err error
+}
+
+func (it *decoderFeatureIterator) Next() bool {
+	if it.err != nil || !it.dec.More() {
+		return false
+	}
+	var f geojson.Feature
+	if err := it.dec.Decode(&f); err != nil {
+		it.err = fmt.Errorf("%w failed to decode feature: %v", ErrParse, err)
+		return false
+	}
+	it.cur = &f
+	return true
+}
+
+func (it *decoderFeatureIterator) Feature() *geojson.Feature { return it.cur }
+
+func (it *decoderFeatureIterator) Err() error { return it.err }
+
+// Mapbox Tiling Service limits the size and feature count of a single
+// tileset source. These defaults are conservative and can be overridden
+// with WithMaxSourceBytes/WithMaxSourceFeatures.
+const (
+	defaultMaxSourceBytes    = 5 * 1024 * 1024 * 1024
+	defaultMaxSourceFeatures = 25000
+)
+
+type featureUploadConfig struct {
+	maxBytes    int64
+	maxFeatures int
+}
+
+// FeatureUploadOption configures chunking behaviour for
+// CreateTilesetSourceFromFeatures.
+type FeatureUploadOption func(*featureUploadConfig)
+
+// WithMaxSourceBytes overrides the encoded byte threshold at which a new
+// tileset source part is started.
+func WithMaxSourceBytes(n int64) FeatureUploadOption {
+	return func(c *featureUploadConfig) { c.maxBytes = n }
+}
+
+// WithMaxSourceFeatures overrides the feature count threshold at which a
+// new tileset source part is started.
+func WithMaxSourceFeatures(n int) FeatureUploadOption {
+	return func(c *featureUploadConfig) { c.maxFeatures = n }
+}
+
+// CreateTilesetSourceFromFeatures encodes the features yielded by iter as
+// newline-delimited GeoJSON and uploads them as one or more tileset
+// sources.
+//
+// To decide up front whether the tileset needs to be split — and hence
+// whether the first source's name needs a part suffix — the first chunk
+// (bounded by the same byte/feature threshold used for later chunks) is
+// buffered in memory. If that is all there is, it is uploaded as a single
+// source named tilesetID, with no suffix. Otherwise it is uploaded as
+// "{tilesetID}-part-0001", and every subsequent chunk is piped into its
+// own upload through an io.Pipe as features are read from iter, without
+// ever materializing more than one chunk at a time.
+func (c *Client) CreateTilesetSourceFromFeatures(ctx context.Context, tilesetID string, iter FeatureIterator, opts ...FeatureUploadOption) ([]NewTilesetSourceResponse, error) {
+	cfg := featureUploadConfig{
+		maxBytes:    defaultMaxSourceBytes,
+		maxFeatures: defaultMaxSourceFeatures,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	firstChunk, pending, features, err := bufferFirstChunk(iter, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if features == 0 {
+		return nil, nil
+	}
+
+	if pending == nil {
+		resp, err := c.CreateTilesetSource(ctx, tilesetID, firstChunk)
+		if err != nil {
+			return nil, err
+		}
+		return []NewTilesetSourceResponse{resp}, nil
+	}
+
+	var responses []NewTilesetSourceResponse
+
+	resp, err := c.CreateTilesetSource(ctx, fmt.Sprintf("%v-part-%04d", tilesetID, 1), firstChunk)
+	if err != nil {
+		return responses, err
+	}
+	responses = append(responses, resp)
+
+	for partIdx := 2; pending != nil; partIdx++ {
+		id := fmt.Sprintf("%v-part-%04d", tilesetID, partIdx)
+
+		resp, err := c.uploadFeaturePart(ctx, id, iter, cfg, &pending)
+		if err != nil {
+			return responses, err
+		}
+		responses = append(responses, resp)
+	}
+
+	if err := iter.Err(); err != nil {
+		return responses, err
+	}
+
+	return responses, nil
+}
+
+// bufferFirstChunk encodes features from iter as newline-delimited GeoJSON
+// into an in-memory buffer until cfg's thresholds would be exceeded or iter
+// is exhausted. It returns the buffered chunk, the feature that didn't fit
+// (nil if iter was exhausted instead), and how many features were buffered.
+func bufferFirstChunk(iter FeatureIterator, cfg featureUploadConfig) (*bytes.Buffer, *geojson.Feature, int, error) {
+	buf := new(bytes.Buffer)
+	var bytesWritten int64
+	var features int
+
+	for iter.Next() {
+		f := iter.Feature()
+		line, err := json.Marshal(f)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("%w failed to encode feature: %v", ErrParse, err)
+		}
+
+		if features > 0 && (bytesWritten+int64(len(line)+1) > cfg.maxBytes || features+1 > cfg.maxFeatures) {
+			return buf, f, features, nil
+		}
+
+		buf.Write(line)
+		buf.WriteByte('\n')
+		bytesWritten += int64(len(line)) + 1
+		features++
+	}
+	if err := iter.Err(); err != nil {
+		return nil, nil, 0, err
+	}
+
+	return buf, nil, features, nil
+}
+
+// uploadFeaturePart encodes features as newline-delimited GeoJSON directly
+// into the io.Pipe backing a single tileset source upload, starting with
+// *pending and continuing to read iter until cfg's thresholds would be
+// exceeded or iter is exhausted. *pending is left holding the first feature
+// of the next part, or nil if iter is exhausted.
+func (c *Client) uploadFeaturePart(ctx context.Context, id string, iter FeatureIterator, cfg featureUploadConfig, pending **geojson.Feature) (NewTilesetSourceResponse, error) {
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+
+	go func() {
+		done <- encodeFeaturePart(pw, iter, cfg, pending)
+	}()
+
+	resp, uploadErr := c.CreateTilesetSource(ctx, id, pr)
+	encodeErr := <-done
+
+	if encodeErr != nil {
+		return resp, encodeErr
+	}
+	return resp, uploadErr
+}
+
+// encodeFeaturePart writes *pending and subsequent features read from iter
+// to w as newline-delimited GeoJSON, closing w when done. It stops once
+// writing another feature would exceed cfg's thresholds, leaving it in
+// *pending for the next part, or once iter is exhausted, leaving *pending
+// nil.
+func encodeFeaturePart(w *io.PipeWriter, iter FeatureIterator, cfg featureUploadConfig, pending **geojson.Feature) error {
+	var bytesWritten int64
+	var features int
+
+	for *pending != nil {
+		line, err := json.Marshal(*pending)
+		if err != nil {
+			err = fmt.Errorf("%w failed to encode feature: %v", ErrParse, err)
+			w.CloseWithError(err)
+			return err
+		}
+
+		if features > 0 && (bytesWritten+int64(len(line)+1) > cfg.maxBytes || features+1 > cfg.maxFeatures) {
+			break
+		}
+
+		if _, err := w.Write(line); err != nil {
+			return err
+		}
+		if _, err := w.Write([]byte("\n")); err != nil {
+			return err
+		}
+		bytesWritten += int64(len(line)) + 1
+		features++
+
+		if !iter.Next() {
+			*pending = nil
+			break
+		}
+		*pending = iter.Feature()
+	}
+
+	return w.Close()
+}
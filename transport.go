@@ -0,0 +1,202 @@
+package mapbox
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RetryPolicy controls how retryTransport retries idempotent requests.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retry attempts after the
+	// initial request. Zero disables retries.
+	MaxRetries int
+
+	// MinBackoff is the base delay for the first retry. Subsequent
+	// retries back off exponentially from this value. Jitter draws each
+	// actual delay from between half the computed delay and the computed
+	// delay.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the computed delay between retries.
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy retries idempotent requests up to 3 times, backing off
+// exponentially from a 500ms base delay up to a 30s cap, before jitter.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries: 3,
+	MinBackoff: 500 * time.Millisecond,
+	MaxBackoff: 30 * time.Second,
+}
+
+// RateLimitError indicates that a request was rejected by Mapbox's rate
+// limiter (HTTP 429) after retryTransport exhausted its retries. It is
+// distinct from ErrOperation so callers can back off at a higher level.
+type RateLimitError struct {
+	// ResetAt is when Mapbox reports the rate limit window resets, if
+	// known.
+	ResetAt time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	if e.ResetAt.IsZero() {
+		return "rate limited by mapbox"
+	}
+	return fmt.Sprintf("rate limited by mapbox, resets at %v", e.ResetAt)
+}
+
+// Unwrap allows errors.Is(err, ErrRateLimited) to match a RateLimitError.
+func (e *RateLimitError) Unwrap() error {
+	return ErrRateLimited
+}
+
+// asRateLimitError returns the *RateLimitError carried by err, if any, so
+// that callers can surface it directly instead of collapsing it into a
+// generic ErrOperation-wrapped string.
+func asRateLimitError(err error) *RateLimitError {
+	var rlErr *RateLimitError
+	if errors.As(err, &rlErr) {
+		return rlErr
+	}
+	return nil
+}
+
+var idempotentMethods = map[string]bool{
+	http.MethodGet:   true,
+	http.MethodPut:   true,
+	http.MethodPatch: true,
+}
+
+// retryTransport wraps an http.RoundTripper to retry idempotent requests on
+// transient failures, honour Mapbox's rate limit headers, and optionally
+// cap outgoing request rate.
+type retryTransport struct {
+	next    http.RoundTripper
+	policy  RetryPolicy
+	limiter *rate.Limiter
+}
+
+func newRetryTransport(next http.RoundTripper, policy RetryPolicy, rps int) *retryTransport {
+	t := &retryTransport{next: next, policy: policy}
+	if rps > 0 {
+		t.limiter = rate.NewLimiter(rate.Limit(rps), rps)
+	}
+	return t
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.limiter != nil {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req)
+
+	// A request can only be retried if its body can be replayed. req.Body
+	// has already been drained by the RoundTrip above, and for bodies like
+	// the io.Pipe backing a multipart upload it cannot be read again, so
+	// GetBody must be present to obtain a fresh copy.
+	canRetry := idempotentMethods[req.Method] && (req.Body == nil || req.Body == http.NoBody || req.GetBody != nil)
+	if !canRetry {
+		return resp, err
+	}
+
+	for attempt := 0; attempt < t.policy.MaxRetries; attempt++ {
+		if err != nil {
+			// Network-level errors are always worth a retry.
+		} else if resp.StatusCode == http.StatusTooManyRequests {
+			// Rate limited: retried like a 5xx. A final response that is
+			// still 429 is reported as a RateLimitError after the loop.
+		} else if resp.StatusCode < 500 {
+			return resp, nil
+		}
+
+		delay := retryAfter(resp)
+		if delay == 0 {
+			delay = backoffDelay(t.policy, attempt)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return resp, req.Context().Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return resp, err
+			}
+			req.Body = body
+		}
+
+		if t.limiter != nil {
+			if err := t.limiter.Wait(req.Context()); err != nil {
+				return resp, err
+			}
+		}
+
+		resp, err = t.next.RoundTrip(req)
+	}
+
+	if err == nil && resp.StatusCode == http.StatusTooManyRequests {
+		return resp, &RateLimitError{ResetAt: parseRateLimitReset(resp.Header)}
+	}
+
+	return resp, err
+}
+
+func backoffDelay(policy RetryPolicy, attempt int) time.Duration {
+	d := policy.MinBackoff * time.Duration(math.Pow(2, float64(attempt)))
+	if d > policy.MaxBackoff {
+		d = policy.MaxBackoff
+	}
+	if d <= 1 {
+		// rand.Int63n panics on a non-positive bound, and there's no
+		// meaningful jitter to add to a zero or 1ns delay anyway.
+		return d
+	}
+	jitter := time.Duration(rand.Int63n(int64(d) / 2))
+	return d/2 + jitter
+}
+
+// retryAfter returns the delay requested by a Retry-After or
+// X-Rate-Limit-Reset header, or zero if neither is present.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	if until := parseRateLimitReset(resp.Header); !until.IsZero() {
+		if d := time.Until(until); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+func parseRateLimitReset(h http.Header) time.Time {
+	v := h.Get("X-Rate-Limit-Reset")
+	if v == "" {
+		return time.Time{}
+	}
+	secs, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.Unix(secs, 0)
+}
@@ -0,0 +1,247 @@
+package mapbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	policy := RetryPolicy{
+		MaxRetries: 5,
+		MinBackoff: 500 * time.Millisecond,
+		MaxBackoff: 2 * time.Second,
+	}
+
+	if d := backoffDelay(policy, 0); d <= 0 || d > policy.MinBackoff {
+		t.Errorf("attempt 0: got %v, want in (0, %v]", d, policy.MinBackoff)
+	}
+
+	if d := backoffDelay(policy, 10); d > policy.MaxBackoff {
+		t.Errorf("attempt 10: got %v, want capped at %v", d, policy.MaxBackoff)
+	}
+
+	// A zero or 1ns delay must not panic rand.Int63n with a non-positive
+	// bound.
+	zero := RetryPolicy{MinBackoff: 0, MaxBackoff: 0}
+	if d := backoffDelay(zero, 0); d != 0 {
+		t.Errorf("zero policy: got %v, want 0", d)
+	}
+
+	one := RetryPolicy{MinBackoff: 1, MaxBackoff: 1}
+	if d := backoffDelay(one, 0); d != 1 {
+		t.Errorf("1ns policy: got %v, want 1ns", d)
+	}
+}
+
+// statusSeqTransport returns the next status code in codes on each
+// RoundTrip call, looping the last entry if it's called more times than
+// len(codes).
+type statusSeqTransport struct {
+	codes []int
+	calls int
+}
+
+func (t *statusSeqTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	idx := t.calls
+	if idx >= len(t.codes) {
+		idx = len(t.codes) - 1
+	}
+	t.calls++
+
+	return &http.Response{
+		StatusCode: t.codes[idx],
+		Header:     make(http.Header),
+		Body:       io.NopCloser(strings.NewReader("")),
+	}, nil
+}
+
+func newTestRequest(t *testing.T, method string, body io.Reader) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(method, "http://example.invalid/x", body)
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	return req
+}
+
+func fastPolicy() RetryPolicy {
+	return RetryPolicy{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+}
+
+func TestRetryTransport_SuccessNoRetry(t *testing.T) {
+	next := &statusSeqTransport{codes: []int{http.StatusOK}}
+	rt := newRetryTransport(next, fastPolicy(), 0)
+
+	resp, err := rt.RoundTrip(newTestRequest(t, http.MethodGet, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %v, want 200", resp.StatusCode)
+	}
+	if next.calls != 1 {
+		t.Errorf("calls = %v, want 1 (no retry on 2xx)", next.calls)
+	}
+}
+
+func TestRetryTransport_ClientErrorNoRetry(t *testing.T) {
+	next := &statusSeqTransport{codes: []int{http.StatusNotFound}}
+	rt := newRetryTransport(next, fastPolicy(), 0)
+
+	resp, err := rt.RoundTrip(newTestRequest(t, http.MethodGet, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("status = %v, want 404", resp.StatusCode)
+	}
+	if next.calls != 1 {
+		t.Errorf("calls = %v, want 1 (no retry on 4xx)", next.calls)
+	}
+}
+
+func TestRetryTransport_ServerErrorRetriesThenSucceeds(t *testing.T) {
+	next := &statusSeqTransport{codes: []int{http.StatusInternalServerError, http.StatusOK}}
+	rt := newRetryTransport(next, fastPolicy(), 0)
+
+	resp, err := rt.RoundTrip(newTestRequest(t, http.MethodGet, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %v, want 200", resp.StatusCode)
+	}
+	if next.calls != 2 {
+		t.Errorf("calls = %v, want 2 (one retry after 5xx)", next.calls)
+	}
+}
+
+func TestRetryTransport_NonIdempotentMethodNotRetried(t *testing.T) {
+	next := &statusSeqTransport{codes: []int{http.StatusInternalServerError, http.StatusOK}}
+	rt := newRetryTransport(next, fastPolicy(), 0)
+
+	resp, err := rt.RoundTrip(newTestRequest(t, http.MethodPost, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("status = %v, want 500 (POST must not be retried)", resp.StatusCode)
+	}
+	if next.calls != 1 {
+		t.Errorf("calls = %v, want 1", next.calls)
+	}
+}
+
+func TestRetryTransport_RateLimitExhaustsIntoRateLimitError(t *testing.T) {
+	next := &statusSeqTransport{codes: []int{http.StatusTooManyRequests}}
+	policy := fastPolicy()
+	policy.MaxRetries = 2
+	rt := newRetryTransport(next, policy, 0)
+
+	_, err := rt.RoundTrip(newTestRequest(t, http.MethodGet, nil))
+
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("err = %v, want *RateLimitError", err)
+	}
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("errors.Is(err, ErrRateLimited) = false, want true")
+	}
+	// The initial request plus MaxRetries retries.
+	if next.calls != policy.MaxRetries+1 {
+		t.Errorf("calls = %v, want %v", next.calls, policy.MaxRetries+1)
+	}
+}
+
+// requestWithReplayableBody returns a request with a strings.Reader body, for
+// which http.NewRequest sets GetBody, so retryTransport is willing to replay
+// it.
+func requestWithReplayableBody(t *testing.T, method, payload string) *http.Request {
+	t.Helper()
+	req, err := http.NewRequest(method, "http://example.invalid/x", strings.NewReader(payload))
+	if err != nil {
+		t.Fatalf("http.NewRequest: %v", err)
+	}
+	return req
+}
+
+func TestRetryTransport_ReplayableBodyIsRetried(t *testing.T) {
+	next := &statusSeqTransport{codes: []int{http.StatusInternalServerError, http.StatusOK}}
+	rt := newRetryTransport(next, fastPolicy(), 0)
+
+	// http.NewRequest populates GetBody automatically for a strings.Reader
+	// body, so this PUT should be retried like any other idempotent
+	// request.
+	req := requestWithReplayableBody(t, http.MethodPut, "payload")
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %v, want 200", resp.StatusCode)
+	}
+	if next.calls != 2 {
+		t.Errorf("calls = %v, want 2", next.calls)
+	}
+}
+
+func TestRetryTransport_NonReplayableBodyNotRetried(t *testing.T) {
+	next := &statusSeqTransport{codes: []int{http.StatusInternalServerError, http.StatusOK}}
+	rt := newRetryTransport(next, fastPolicy(), 0)
+
+	// An io.Pipe-backed body (as used for multipart uploads) has no
+	// GetBody, and req.Body has already been drained by the first
+	// RoundTrip, so it must not be retried.
+	pr, pw := io.Pipe()
+	go func() {
+		pw.Write([]byte("payload"))
+		pw.Close()
+	}()
+	req := newTestRequest(t, http.MethodPut, pr)
+	req.GetBody = nil
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.StatusCode != http.StatusInternalServerError {
+		t.Errorf("status = %v, want 500 (non-replayable body must not be retried)", resp.StatusCode)
+	}
+	if next.calls != 1 {
+		t.Errorf("calls = %v, want 1", next.calls)
+	}
+}
+
+// TestDoMultipart_PropagatesTransportErrorOnRetry checks that doMultipart
+// never returns (nil, nil) for an upload sent through a retryTransport. The
+// multipart body is an io.Pipe without GetBody, so retryTransport does not
+// retry the first 500 response and hands it back to doMultipart as is.
+func TestDoMultipart_PropagatesTransportErrorOnRetry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	httpClient := &http.Client{
+		Transport: newRetryTransport(http.DefaultTransport, fastPolicy(), 0),
+	}
+
+	resp, err := putMultipart(context.Background(), httpClient, server.URL, "f", bytes.NewReader([]byte("{}\n")))
+	if resp == nil && err == nil {
+		t.Fatal("got (nil, nil); doMultipart must not silently discard a transport error")
+	}
+}